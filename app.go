@@ -3,11 +3,16 @@ package kratos
 import (
 	"context"
 	"errors"
+	"fmt"
+	"net/url"
 	"os"
 	"os/signal"
+	"sync"
 	"syscall"
 
+	"github.com/go-kratos/kratos/v2/health"
 	"github.com/go-kratos/kratos/v2/log"
+	"github.com/go-kratos/kratos/v2/metrics"
 	"github.com/go-kratos/kratos/v2/registry"
 	"github.com/go-kratos/kratos/v2/transport"
 
@@ -17,19 +22,100 @@ import (
 
 // App is an application components lifecycle manager
 type App struct {
-	opts     options
-	ctx      context.Context
-	cancel   func()
+	opts      options
+	ctx       context.Context
+	cancel    func()
+	lifecycle *Lifecycle
+	stopOnce  sync.Once
+	stopErr   error
+	healthReg *health.Registry
+
+	// mu guards instance, log, sigs and sigCh: ReloadableOptions (config.go)
+	// mutate them from the config-watcher goroutine while Run, Stop,
+	// watchHealth and the signal handler read them from other goroutines.
+	mu       sync.Mutex
 	instance *registry.ServiceInstance
 	log      *log.Helper
+	sigs     []os.Signal
+	sigCh    chan os.Signal
+
+	serverUp                metrics.Gauge
+	registryRegisterTotal   metrics.Counter
+	registryDeregisterTotal metrics.Counter
+	requestLatency          metrics.Histogram
+}
+
+// logger returns the App's current log.Helper.
+func (a *App) logger() *log.Helper {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.log
+}
+
+// setLogger swaps the App's log.Helper.
+func (a *App) setLogger(l *log.Helper) {
+	a.mu.Lock()
+	a.log = l
+	a.mu.Unlock()
+}
+
+// currentSigs returns the signals the App's shutdown handler is
+// currently subscribed to.
+func (a *App) currentSigs() []os.Signal {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.sigs
+}
+
+// setSigs replaces the signals the App's shutdown handler is subscribed
+// to, re-notifying on sigCh if Run has already started listening.
+func (a *App) setSigs(sigs []os.Signal) {
+	a.mu.Lock()
+	a.sigs = sigs
+	ch := a.sigCh
+	a.mu.Unlock()
+	if ch != nil {
+		signal.Stop(ch)
+		signal.Notify(ch, sigs...)
+	}
+}
+
+// instanceSnapshot returns a copy of the App's current ServiceInstance,
+// safe to hand to the registry without racing a concurrent ReloadMetadata
+// or ReloadEndpoints.
+func (a *App) instanceSnapshot() *registry.ServiceInstance {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	inst := *a.instance
+	if a.instance.Metadata != nil {
+		md := make(map[string]string, len(a.instance.Metadata))
+		for k, v := range a.instance.Metadata {
+			md[k] = v
+		}
+		inst.Metadata = md
+	}
+	if a.instance.Endpoints != nil {
+		inst.Endpoints = append([]*url.URL(nil), a.instance.Endpoints...)
+	}
+	return &inst
+}
+
+// updateInstance mutates the App's ServiceInstance under mu, so it can't
+// tear against a concurrent instanceSnapshot.
+func (a *App) updateInstance(fn func(*registry.ServiceInstance)) {
+	a.mu.Lock()
+	fn(a.instance)
+	a.mu.Unlock()
 }
 
 // New create an application lifecycle manager.
 func New(opts ...Option) *App {
 	options := options{
-		ctx:    context.Background(),
-		logger: log.DefaultLogger,
-		sigs:   []os.Signal{syscall.SIGTERM, syscall.SIGQUIT, syscall.SIGINT},
+		ctx:                    context.Background(),
+		logger:                 log.DefaultLogger,
+		sigs:                   []os.Signal{syscall.SIGTERM, syscall.SIGQUIT, syscall.SIGINT},
+		lifecycle:              NewLifecycle(),
+		healthFailureThreshold: 3,
 	}
 	if id, err := uuid.NewUUID(); err == nil {
 		options.id = id.String()
@@ -37,13 +123,23 @@ func New(opts ...Option) *App {
 	for _, o := range opts {
 		o(&options)
 	}
+	helper := log.NewHelper("app", options.logger)
+	if options.idProvider != nil {
+		if id, err := options.idProvider.InstanceID(options.name); err == nil {
+			options.id = id
+		} else {
+			helper.Errorw("msg", "id provider failed, falling back to random id", "error", err)
+		}
+	}
 	ctx, cancel := context.WithCancel(options.ctx)
 	return &App{
-		opts:     options,
-		ctx:      ctx,
-		cancel:   cancel,
-		instance: serviceInstance(options),
-		log:      log.NewHelper("app", options.logger),
+		opts:      options,
+		ctx:       ctx,
+		cancel:    cancel,
+		instance:  serviceInstance(options),
+		log:       helper,
+		sigs:      options.sigs,
+		lifecycle: options.lifecycle,
 	}
 }
 
@@ -62,9 +158,17 @@ func (a *App) Registry() registry.Registry {
 	return a.opts.registry
 }
 
+// Lifecycle returns the application's Lifecycle, letting callers append
+// OnStart/OnStop hooks before Run. Hooks registered via WithHooks are
+// already present; App.Lifecycle() is for hooks appended after New, e.g.
+// from plugins wired in by the caller.
+func (a *App) Lifecycle() *Lifecycle {
+	return a.lifecycle
+}
+
 // Run executes all OnStart hooks registered with the application's Lifecycle.
 func (a *App) Run() error {
-	a.log.Infow(
+	a.logger().Infow(
 		"service_id", a.opts.id,
 		"service_name", a.opts.name,
 		"version", a.opts.version,
@@ -72,29 +176,68 @@ func (a *App) Run() error {
 	g, ctx := errgroup.WithContext(a.ctx)
 	for _, srv := range a.opts.servers {
 		srv := srv
-		g.Go(func() error {
-			<-ctx.Done() // wait for stop signal
-			return srv.Stop()
-		})
-		g.Go(func() error {
-			return srv.Start()
+		a.lifecycle.Append(Hook{
+			OnStart: func(context.Context) error {
+				g.Go(srv.Start)
+				if a.serverUp != nil {
+					a.serverUp.Set(1, fmt.Sprintf("%T", srv))
+				}
+				return nil
+			},
+			OnStop: func(ctx context.Context) error {
+				if a.serverUp != nil {
+					a.serverUp.Set(0, fmt.Sprintf("%T", srv))
+				}
+				if err := stopServer(ctx, srv); err != nil {
+					a.logger().Errorw("msg", "server did not stop cleanly", "error", err)
+					return err
+				}
+				return nil
+			},
 		})
 	}
+	a.setupObservability(g)
+	if err := a.setupConfigWatcher(g); err != nil {
+		return err
+	}
+	if err := a.lifecycle.Start(ctx, a.opts.hookStartTimeout); err != nil {
+		return err
+	}
 	if a.opts.registry != nil {
-		if err := a.opts.registry.Register(a.instance); err != nil {
+		if err := a.opts.registry.Register(a.instanceSnapshot()); err != nil {
 			return err
 		}
+		if a.registryRegisterTotal != nil {
+			a.registryRegisterTotal.Add(1)
+		}
+	}
+	if a.opts.healthCheckInterval > 0 {
+		g.Go(func() error {
+			a.watchHealth(ctx)
+			return nil
+		})
 	}
 	c := make(chan os.Signal, 1)
-	signal.Notify(c, a.opts.sigs...)
+	a.mu.Lock()
+	a.sigCh = c
+	a.mu.Unlock()
+	signal.Notify(c, a.currentSigs()...)
 	g.Go(func() error {
-		for {
-			select {
-			case <-ctx.Done():
-				return ctx.Err()
-			case <-c:
-				a.Stop()
-			}
+		select {
+		case <-ctx.Done():
+		case <-c:
+		}
+		stopped := make(chan error, 1)
+		go func() { stopped <- a.Stop() }()
+		select {
+		case err := <-stopped:
+			return err
+		case <-c:
+			// A second signal during the shutdown window means the
+			// caller wants out now, not a graceful drain.
+			a.logger().Warn("kratos: received a second signal, forcing immediate exit")
+			os.Exit(1)
+			return nil
 		}
 	})
 	if err := g.Wait(); err != nil && !errors.Is(err, context.Canceled) {
@@ -103,17 +246,55 @@ func (a *App) Run() error {
 	return nil
 }
 
-// Stop gracefully stops the application.
+// Stop gracefully stops the application, running the Lifecycle's OnStop
+// hooks (including the transport servers) in reverse registration order.
+// If WithStopTimeout was set, every step (before/after-stop hooks, server
+// stop, and registry deregistration) is bound by that deadline; a server
+// that doesn't stop in time is logged and abandoned rather than blocking
+// the rest of shutdown. Stop is safe to call more than once; only the
+// first call does any work.
 func (a *App) Stop() error {
-	if a.opts.registry != nil {
-		if err := a.opts.registry.Deregister(a.instance); err != nil {
-			return err
+	a.stopOnce.Do(func() {
+		ctx := context.Background()
+		if a.opts.stopTimeout > 0 {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, a.opts.stopTimeout)
+			defer cancel()
 		}
-	}
-	if a.cancel != nil {
-		a.cancel()
-	}
-	return nil
+		for _, fn := range a.opts.beforeStop {
+			if err := fn(ctx); err != nil {
+				a.logger().Errorw("msg", "before-stop hook failed", "error", err)
+			}
+		}
+		if a.opts.registry != nil {
+			inst := a.instanceSnapshot()
+			done := make(chan error, 1)
+			go func() { done <- a.opts.registry.Deregister(inst) }()
+			select {
+			case err := <-done:
+				if err != nil {
+					a.stopErr = err
+				}
+			case <-ctx.Done():
+				a.stopErr = fmt.Errorf("kratos: registry deregistration did not finish within the shutdown deadline: %w", ctx.Err())
+			}
+			if a.registryDeregisterTotal != nil {
+				a.registryDeregisterTotal.Add(1)
+			}
+		}
+		if err := a.lifecycle.Stop(ctx); err != nil {
+			a.logger().Error(err)
+		}
+		for _, fn := range a.opts.afterStop {
+			if err := fn(ctx); err != nil {
+				a.logger().Errorw("msg", "after-stop hook failed", "error", err)
+			}
+		}
+		if a.cancel != nil {
+			a.cancel()
+		}
+	})
+	return a.stopErr
 }
 
 func serviceInstance(o options) *registry.ServiceInstance {
@@ -0,0 +1,224 @@
+package kratos
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"syscall"
+
+	"github.com/go-kratos/kratos/v2/config"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/go-kratos/kratos/v2/registry"
+
+	"golang.org/x/sync/errgroup"
+)
+
+// ErrRestartRequired is reported when a config change can't be applied
+// without restarting transports (e.g. a bind address or TLS material).
+// The App logs it and leaves the running configuration untouched; the
+// caller is expected to restart the process to pick up the change.
+var ErrRestartRequired = errors.New("kratos: this change requires an application restart")
+
+// ReloadableOption mutates an already-running App in response to a
+// config change. Unlike Option, it applies after New, so it may only
+// touch state that's safe to change without restarting transports.
+type ReloadableOption func(a *App) error
+
+// ReloadLoggerLevel sets the level the App's logger filters at.
+func ReloadLoggerLevel(level log.Level) ReloadableOption {
+	return func(a *App) error {
+		a.setLogger(log.NewHelper("app", log.NewFilter(a.opts.logger, log.FilterLevel(level))))
+		return nil
+	}
+}
+
+// ReloadMetadata merges md into the running ServiceInstance's metadata
+// and republishes it to the registry.
+func ReloadMetadata(md map[string]string) ReloadableOption {
+	return func(a *App) error {
+		a.updateInstance(func(inst *registry.ServiceInstance) {
+			if inst.Metadata == nil {
+				inst.Metadata = make(map[string]string, len(md))
+			}
+			for k, v := range md {
+				inst.Metadata[k] = v
+			}
+		})
+		return a.republish()
+	}
+}
+
+// ReloadEndpoints replaces the running ServiceInstance's endpoints and
+// republishes it to the registry.
+func ReloadEndpoints(endpoints ...*url.URL) ReloadableOption {
+	return func(a *App) error {
+		a.updateInstance(func(inst *registry.ServiceInstance) {
+			inst.Endpoints = endpoints
+		})
+		return a.republish()
+	}
+}
+
+// ReloadSignals re-subscribes App's shutdown signal handler to sigs,
+// replacing whatever was set by Signal or the default.
+func ReloadSignals(sigs ...os.Signal) ReloadableOption {
+	return func(a *App) error {
+		a.setSigs(sigs)
+		return nil
+	}
+}
+
+// RegistryUpdater is implemented by registry.Registry backends that can
+// publish a changed ServiceInstance in place. Backends that don't
+// implement it fall back to a Deregister+Register cycle.
+type RegistryUpdater interface {
+	Update(instance *registry.ServiceInstance) error
+}
+
+// republish pushes a snapshot of a.instance to the registry, preferring
+// Update for backends that implement it over a full Deregister+Register.
+func (a *App) republish() error {
+	if a.opts.registry == nil {
+		return nil
+	}
+	inst := a.instanceSnapshot()
+	if u, ok := a.opts.registry.(RegistryUpdater); ok {
+		return u.Update(inst)
+	}
+	if err := a.opts.registry.Deregister(inst); err != nil {
+		return err
+	}
+	return a.opts.registry.Register(inst)
+}
+
+// WithConfigWatcher registers src as a source of hot-reloadable config.
+// Run starts a background watcher that reacts to changes under keys,
+// diff-applying the safe subset of options this package understands
+// ("log.level", "metadata", "endpoints", "signals") without restarting
+// transports. A change under any other key is logged with
+// ErrRestartRequired instead of applied. If keys is empty, every key the
+// source reports is considered.
+func WithConfigWatcher(src config.Source, keys ...string) Option {
+	return func(o *options) {
+		o.configSource = src
+		o.configKeys = keys
+	}
+}
+
+// configReloaders maps the well-known keys WithConfigWatcher understands
+// to the ReloadableOption each decodes into.
+var configReloaders = map[string]func(value []byte) (ReloadableOption, error){
+	"log.level": func(value []byte) (ReloadableOption, error) {
+		var s string
+		if err := json.Unmarshal(value, &s); err != nil {
+			return nil, err
+		}
+		return ReloadLoggerLevel(log.ParseLevel(s)), nil
+	},
+	"metadata": func(value []byte) (ReloadableOption, error) {
+		var md map[string]string
+		if err := json.Unmarshal(value, &md); err != nil {
+			return nil, err
+		}
+		return ReloadMetadata(md), nil
+	},
+	"endpoints": func(value []byte) (ReloadableOption, error) {
+		var raw []string
+		if err := json.Unmarshal(value, &raw); err != nil {
+			return nil, err
+		}
+		endpoints := make([]*url.URL, 0, len(raw))
+		for _, s := range raw {
+			u, err := url.Parse(s)
+			if err != nil {
+				return nil, err
+			}
+			endpoints = append(endpoints, u)
+		}
+		return ReloadEndpoints(endpoints...), nil
+	},
+	"signals": func(value []byte) (ReloadableOption, error) {
+		var names []string
+		if err := json.Unmarshal(value, &names); err != nil {
+			return nil, err
+		}
+		sigs := make([]os.Signal, 0, len(names))
+		for _, n := range names {
+			sig, ok := signalByName[n]
+			if !ok {
+				return nil, fmt.Errorf("kratos: unknown signal %q", n)
+			}
+			sigs = append(sigs, sig)
+		}
+		return ReloadSignals(sigs...), nil
+	},
+}
+
+var signalByName = map[string]os.Signal{
+	"SIGTERM": syscall.SIGTERM,
+	"SIGINT":  syscall.SIGINT,
+	"SIGQUIT": syscall.SIGQUIT,
+	"SIGHUP":  syscall.SIGHUP,
+}
+
+// setupConfigWatcher starts watching a.opts.configSource, if one was
+// configured, and appends a Lifecycle hook so the watcher goroutine
+// starts and stops alongside the rest of the application. It must run
+// before a.lifecycle.Start.
+func (a *App) setupConfigWatcher(g *errgroup.Group) error {
+	if a.opts.configSource == nil {
+		return nil
+	}
+	watcher, err := a.opts.configSource.Watch()
+	if err != nil {
+		return err
+	}
+	allowed := make(map[string]bool, len(a.opts.configKeys))
+	for _, k := range a.opts.configKeys {
+		allowed[k] = true
+	}
+	a.lifecycle.Append(Hook{
+		OnStart: func(context.Context) error {
+			g.Go(func() error {
+				for {
+					kvs, err := watcher.Next()
+					if err != nil {
+						// Next returns an error once Stop has been
+						// called during shutdown; nothing left to do.
+						return nil
+					}
+					for _, kv := range kvs {
+						if len(allowed) > 0 && !allowed[kv.Key] {
+							continue
+						}
+						a.applyConfigChange(kv)
+					}
+				}
+			})
+			return nil
+		},
+		OnStop: func(context.Context) error {
+			return watcher.Stop()
+		},
+	})
+	return nil
+}
+
+func (a *App) applyConfigChange(kv *config.KeyValue) {
+	decode, ok := configReloaders[kv.Key]
+	if !ok {
+		a.logger().Errorw("msg", "config change requires restart", "key", kv.Key, "error", ErrRestartRequired)
+		return
+	}
+	opt, err := decode(kv.Value)
+	if err != nil {
+		a.logger().Errorw("msg", "failed to decode config change", "key", kv.Key, "error", err)
+		return
+	}
+	if err := opt(a); err != nil {
+		a.logger().Errorw("msg", "failed to apply config change", "key", kv.Key, "error", err)
+	}
+}
@@ -0,0 +1,95 @@
+// Package health provides liveness/readiness checks that an App can run
+// on a schedule to gate registry registration and to back /healthz and
+// /readyz HTTP handlers.
+package health
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+)
+
+// Kind classifies a Checker as part of the liveness or readiness
+// surface. Liveness answers "is the process alive" and is reported but
+// never acted on; readiness answers "can this instance serve traffic
+// right now" and is what gates registry registration.
+type Kind int
+
+const (
+	// Readiness checks gate whether the instance stays registered.
+	Readiness Kind = iota
+	// Liveness checks are reported but never deregister the instance.
+	Liveness
+)
+
+// Checker reports whether a dependency or subsystem is healthy.
+type Checker interface {
+	Check(ctx context.Context) error
+}
+
+// CheckerFunc adapts a function to a Checker.
+type CheckerFunc func(ctx context.Context) error
+
+// Check implements Checker.
+func (f CheckerFunc) Check(ctx context.Context) error { return f(ctx) }
+
+type entry struct {
+	kind    Kind
+	checker Checker
+}
+
+// Registry collects named Checkers and reports aggregate liveness and
+// readiness. It is safe for concurrent use.
+type Registry struct {
+	mu     sync.Mutex
+	checks map[string]entry
+}
+
+// NewRegistry returns an empty health Registry.
+func NewRegistry() *Registry {
+	return &Registry{checks: make(map[string]entry)}
+}
+
+// Add registers a named Checker under kind. Adding the same name twice
+// replaces the previous checker.
+func (r *Registry) Add(name string, kind Kind, c Checker) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.checks[name] = entry{kind: kind, checker: c}
+}
+
+// Check runs every Checker of kind and returns the first error
+// encountered, naming the failing check.
+func (r *Registry) Check(ctx context.Context, kind Kind) error {
+	r.mu.Lock()
+	entries := make(map[string]entry, len(r.checks))
+	for name, e := range r.checks {
+		entries[name] = e
+	}
+	r.mu.Unlock()
+	for name, e := range entries {
+		if e.kind != kind {
+			continue
+		}
+		if err := e.checker.Check(ctx); err != nil {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// Handler returns an http.Handler that runs every Checker of kind,
+// responding 200 if all pass and 503 with the failing check's error
+// otherwise. Mount it at /healthz (Liveness) or /readyz (Readiness).
+func (r *Registry) Handler(kind Kind) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if err := r.Check(req.Context(), kind); err != nil {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			_, _ = w.Write([]byte(err.Error()))
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte("ok"))
+	})
+}
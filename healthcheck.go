@@ -0,0 +1,106 @@
+package kratos
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/health"
+	"github.com/go-kratos/kratos/v2/registry"
+)
+
+// Heartbeater is implemented by registry.Registry backends that can
+// refresh an already-registered instance's TTL lease directly (e.g.
+// consul, nats-kv, etcd), instead of a full Deregister+Register cycle.
+// The health monitor uses Heartbeat when the configured registry
+// implements it and otherwise just re-Registers on recovery.
+type Heartbeater interface {
+	Heartbeat(ctx context.Context, instance *registry.ServiceInstance) error
+}
+
+// AddHealthCheck registers a readiness Checker under name. Readiness
+// checks run on the interval set by WithHealthCheckInterval; once one
+// fails for WithHealthFailureThreshold consecutive intervals, the
+// instance is deregistered until readiness recovers.
+func (a *App) AddHealthCheck(name string, c health.Checker) {
+	a.health().Add(name, health.Readiness, c)
+}
+
+// AddLivenessCheck registers a liveness Checker under name. Liveness
+// checks are only surfaced through HealthzHandler; they never affect
+// registration.
+func (a *App) AddLivenessCheck(name string, c health.Checker) {
+	a.health().Add(name, health.Liveness, c)
+}
+
+// HealthzHandler returns an http.Handler reporting aggregate liveness,
+// for mounting at /healthz on the HTTP transport.
+func (a *App) HealthzHandler() http.Handler {
+	return a.health().Handler(health.Liveness)
+}
+
+// ReadyzHandler returns an http.Handler reporting aggregate readiness,
+// for mounting at /readyz on the HTTP transport.
+func (a *App) ReadyzHandler() http.Handler {
+	return a.health().Handler(health.Readiness)
+}
+
+func (a *App) health() *health.Registry {
+	if a.healthReg == nil {
+		a.healthReg = health.NewRegistry()
+	}
+	return a.healthReg
+}
+
+// watchHealth runs until ctx is canceled, periodically checking
+// readiness and keeping the registry registration in sync with it:
+// deregistering after healthFailureThreshold consecutive failures and
+// re-registering on recovery. When the registry supports it, a passing
+// check instead refreshes its TTL lease via Heartbeat.
+func (a *App) watchHealth(ctx context.Context) {
+	if a.opts.healthCheckInterval <= 0 {
+		return
+	}
+	ticker := time.NewTicker(a.opts.healthCheckInterval)
+	defer ticker.Stop()
+	failures := 0
+	degraded := false
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := a.health().Check(ctx, health.Readiness); err != nil {
+				failures++
+				if !degraded && failures >= a.opts.healthFailureThreshold {
+					degraded = true
+					a.logger().Errorw("msg", "readiness failing, deregistering instance", "error", err)
+					if a.opts.registry != nil {
+						if derr := a.opts.registry.Deregister(a.instanceSnapshot()); derr != nil {
+							a.logger().Errorw("msg", "failed to deregister unhealthy instance", "error", derr)
+						}
+					}
+				}
+				continue
+			}
+			failures = 0
+			if degraded {
+				degraded = false
+				a.logger().Infow("msg", "readiness recovered, re-registering instance")
+				if a.opts.registry != nil {
+					if rerr := a.opts.registry.Register(a.instanceSnapshot()); rerr != nil {
+						a.logger().Errorw("msg", "failed to re-register recovered instance", "error", rerr)
+					}
+				}
+				continue
+			}
+			if a.opts.registry != nil {
+				if hb, ok := a.opts.registry.(Heartbeater); ok {
+					if herr := hb.Heartbeat(ctx, a.instanceSnapshot()); herr != nil {
+						a.logger().Errorw("msg", "heartbeat failed", "error", herr)
+					}
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,107 @@
+package kratos
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// IDProvider supplies the ServiceInstance.ID used when an App registers
+// itself. Implementations should return the same ID across process
+// restarts on the same host, so registry backends can recognize a
+// re-registering instance instead of treating it as a brand-new one.
+type IDProvider interface {
+	InstanceID(serviceName string) (string, error)
+}
+
+// IDProviderFunc adapts a function to an IDProvider.
+type IDProviderFunc func(serviceName string) (string, error)
+
+// InstanceID implements IDProvider.
+func (f IDProviderFunc) InstanceID(serviceName string) (string, error) {
+	return f(serviceName)
+}
+
+// WithIDProvider sets the IDProvider used to derive the service instance
+// ID, in place of the random UUID New assigns by default.
+func WithIDProvider(p IDProvider) Option {
+	return func(o *options) { o.idProvider = p }
+}
+
+// EnvIDProvider returns an IDProvider that reads the instance ID from the
+// named environment variable, failing if it is unset.
+func EnvIDProvider(key string) IDProvider {
+	return IDProviderFunc(func(string) (string, error) {
+		id := os.Getenv(key)
+		if id == "" {
+			return "", fmt.Errorf("kratos: environment variable %q is not set", key)
+		}
+		return id, nil
+	})
+}
+
+// HostnameIDProvider returns an IDProvider that derives the instance ID
+// from the machine hostname, qualified by the service name so that
+// multiple services on the same host don't collide.
+func HostnameIDProvider() IDProvider {
+	return IDProviderFunc(func(serviceName string) (string, error) {
+		host, err := os.Hostname()
+		if err != nil {
+			return "", err
+		}
+		if serviceName == "" {
+			return host, nil
+		}
+		return serviceName + "-" + host, nil
+	})
+}
+
+// FileIDProvider returns an IDProvider backed by a file at
+// dir/<serviceName>.id. The first call mints a UUID and persists it;
+// later calls against the same dir read the persisted value back, so
+// restarts on the same host reuse the same ID. If dir is empty, it
+// defaults to $XDG_STATE_HOME/kratos, falling back to
+// $HOME/.local/state/kratos.
+func FileIDProvider(dir string) IDProvider {
+	return IDProviderFunc(func(serviceName string) (string, error) {
+		base := dir
+		if base == "" {
+			base = defaultStateDir()
+		}
+		name := serviceName
+		if name == "" {
+			name = "app"
+		}
+		path := filepath.Join(base, name+".id")
+		if b, err := os.ReadFile(path); err == nil {
+			if id := strings.TrimSpace(string(b)); id != "" {
+				return id, nil
+			}
+		}
+		id, err := uuid.NewUUID()
+		if err != nil {
+			return "", err
+		}
+		if err := os.MkdirAll(base, 0o755); err != nil {
+			return "", err
+		}
+		if err := os.WriteFile(path, []byte(id.String()), 0o644); err != nil {
+			return "", err
+		}
+		return id.String(), nil
+	})
+}
+
+func defaultStateDir() string {
+	if xdg := os.Getenv("XDG_STATE_HOME"); xdg != "" {
+		return filepath.Join(xdg, "kratos")
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return filepath.Join(os.TempDir(), "kratos")
+	}
+	return filepath.Join(home, ".local", "state", "kratos")
+}
@@ -0,0 +1,62 @@
+package kratos
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestFileIDProviderMintsThenPersists(t *testing.T) {
+	dir := t.TempDir()
+	provider := FileIDProvider(dir)
+
+	first, err := provider.InstanceID("billing")
+	if err != nil {
+		t.Fatalf("InstanceID() = %v, want nil error", err)
+	}
+	if first == "" {
+		t.Fatal("InstanceID() returned an empty id")
+	}
+
+	path := filepath.Join(dir, "billing.id")
+	if _, err := os.Stat(path); err != nil {
+		t.Fatalf("expected %s to be written, got %v", path, err)
+	}
+
+	// A fresh provider instance reading the same dir must read back the
+	// minted id instead of minting a new one.
+	second, err := FileIDProvider(dir).InstanceID("billing")
+	if err != nil {
+		t.Fatalf("InstanceID() = %v, want nil error", err)
+	}
+	if second != first {
+		t.Fatalf("InstanceID() = %q on second call, want persisted %q", second, first)
+	}
+
+	// Calling it again on the very same provider must also read back, not
+	// mint again.
+	third, err := provider.InstanceID("billing")
+	if err != nil {
+		t.Fatalf("InstanceID() = %v, want nil error", err)
+	}
+	if third != first {
+		t.Fatalf("InstanceID() = %q on third call, want persisted %q", third, first)
+	}
+}
+
+func TestFileIDProviderPerServiceIsolation(t *testing.T) {
+	dir := t.TempDir()
+	provider := FileIDProvider(dir)
+
+	billingID, err := provider.InstanceID("billing")
+	if err != nil {
+		t.Fatalf("InstanceID(billing) = %v, want nil error", err)
+	}
+	ordersID, err := provider.InstanceID("orders")
+	if err != nil {
+		t.Fatalf("InstanceID(orders) = %v, want nil error", err)
+	}
+	if billingID == ordersID {
+		t.Fatalf("billing and orders got the same id %q, want distinct per-service files", billingID)
+	}
+}
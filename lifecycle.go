@@ -0,0 +1,82 @@
+package kratos
+
+import (
+	"context"
+	"time"
+)
+
+// Hook is a pair of lifecycle callbacks. OnStart is invoked when the
+// application starts, OnStop when it stops. Either field may be nil.
+type Hook struct {
+	OnStart func(context.Context) error
+	OnStop  func(context.Context) error
+}
+
+// Lifecycle sequences OnStart/OnStop callbacks for components managed by
+// an App, similar in spirit to fx.Lifecycle. Hooks start in registration
+// order and stop in the reverse order, so a hook can safely depend on
+// anything registered before it.
+type Lifecycle struct {
+	hooks   []Hook
+	started int
+}
+
+// NewLifecycle returns an empty Lifecycle.
+func NewLifecycle() *Lifecycle {
+	return &Lifecycle{}
+}
+
+// Append registers a Hook. It is not safe to call Append concurrently
+// with Start or Stop.
+func (l *Lifecycle) Append(h Hook) {
+	l.hooks = append(l.hooks, h)
+}
+
+// Start runs every OnStart hook in registration order, using ctx (bounded
+// by timeout when timeout > 0) for each individual call. A hook that has
+// not returned when its timeout expires fails with the context's error
+// and is not waited on. If a hook fails, Start stops there and unwinds by
+// running OnStop, in reverse order, for every hook that already started.
+func (l *Lifecycle) Start(ctx context.Context, timeout time.Duration) error {
+	for _, h := range l.hooks {
+		if start := h.OnStart; start != nil {
+			var err error
+			if timeout > 0 {
+				hctx, cancel := context.WithTimeout(ctx, timeout)
+				done := make(chan error, 1)
+				go func() { done <- start(hctx) }()
+				select {
+				case err = <-done:
+				case <-hctx.Done():
+					err = hctx.Err()
+				}
+				cancel()
+			} else {
+				err = start(ctx)
+			}
+			if err != nil {
+				_ = l.Stop(ctx)
+				return err
+			}
+		}
+		l.started++
+	}
+	return nil
+}
+
+// Stop runs OnStop for every hook that successfully started, in reverse
+// registration order. It keeps going after an individual hook error so
+// that every started component gets a chance to shut down, returning the
+// first error encountered.
+func (l *Lifecycle) Stop(ctx context.Context) error {
+	var err error
+	for i := l.started - 1; i >= 0; i-- {
+		if h := l.hooks[i]; h.OnStop != nil {
+			if e := h.OnStop(ctx); e != nil && err == nil {
+				err = e
+			}
+		}
+	}
+	l.started = 0
+	return err
+}
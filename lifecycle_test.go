@@ -0,0 +1,114 @@
+package kratos
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestLifecycleStartStopOrder(t *testing.T) {
+	var events []string
+	l := NewLifecycle()
+	for _, name := range []string{"a", "b", "c"} {
+		name := name
+		l.Append(Hook{
+			OnStart: func(context.Context) error {
+				events = append(events, "start:"+name)
+				return nil
+			},
+			OnStop: func(context.Context) error {
+				events = append(events, "stop:"+name)
+				return nil
+			},
+		})
+	}
+
+	if err := l.Start(context.Background(), 0); err != nil {
+		t.Fatalf("Start() = %v, want nil", err)
+	}
+	want := []string{"start:a", "start:b", "start:c"}
+	if !equalStrings(events, want) {
+		t.Fatalf("start order = %v, want %v", events, want)
+	}
+
+	if err := l.Stop(context.Background()); err != nil {
+		t.Fatalf("Stop() = %v, want nil", err)
+	}
+	want = append(want, "stop:c", "stop:b", "stop:a")
+	if !equalStrings(events, want) {
+		t.Fatalf("full order = %v, want %v", events, want)
+	}
+}
+
+func TestLifecycleStartUnwindsOnFailure(t *testing.T) {
+	var events []string
+	errBoom := errors.New("boom")
+	l := NewLifecycle()
+	l.Append(Hook{
+		OnStart: func(context.Context) error { events = append(events, "start:a"); return nil },
+		OnStop:  func(context.Context) error { events = append(events, "stop:a"); return nil },
+	})
+	l.Append(Hook{
+		OnStart: func(context.Context) error { events = append(events, "start:b"); return errBoom },
+		OnStop:  func(context.Context) error { events = append(events, "stop:b"); return nil },
+	})
+	l.Append(Hook{
+		OnStart: func(context.Context) error { events = append(events, "start:c"); return nil },
+		OnStop:  func(context.Context) error { events = append(events, "stop:c"); return nil },
+	})
+
+	err := l.Start(context.Background(), 0)
+	if !errors.Is(err, errBoom) {
+		t.Fatalf("Start() = %v, want %v", err, errBoom)
+	}
+
+	// c never started, so it must not appear at all; a started before b
+	// failed, so only a gets unwound, in reverse of its start order.
+	want := []string{"start:a", "start:b", "stop:a"}
+	if !equalStrings(events, want) {
+		t.Fatalf("events = %v, want %v", events, want)
+	}
+}
+
+func TestLifecycleStopCollectsFirstError(t *testing.T) {
+	errA := errors.New("a failed")
+	errB := errors.New("b failed")
+	l := NewLifecycle()
+	l.Append(Hook{
+		OnStart: func(context.Context) error { return nil },
+		OnStop:  func(context.Context) error { return errA },
+	})
+	l.Append(Hook{
+		OnStart: func(context.Context) error { return nil },
+		OnStop:  func(context.Context) error { return errB },
+	})
+
+	if err := l.Start(context.Background(), 0); err != nil {
+		t.Fatalf("Start() = %v, want nil", err)
+	}
+
+	var stopped []string
+	l.hooks[0].OnStop = func(context.Context) error { stopped = append(stopped, "a"); return errA }
+	l.hooks[1].OnStop = func(context.Context) error { stopped = append(stopped, "b"); return errB }
+
+	// Stop runs reverse order (b, then a) and keeps going after b's error,
+	// so the returned error is b's even though a also failed.
+	if err := l.Stop(context.Background()); !errors.Is(err, errB) {
+		t.Fatalf("Stop() = %v, want %v", err, errB)
+	}
+	if want := []string{"b", "a"}; !equalStrings(stopped, want) {
+		t.Fatalf("stop order = %v, want %v", stopped, want)
+	}
+}
+
+func equalStrings(got, want []string) bool {
+	if len(got) != len(want) {
+		return false
+	}
+	for i := range got {
+		if got[i] != want[i] {
+			return false
+		}
+	}
+	return true
+}
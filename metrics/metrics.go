@@ -0,0 +1,37 @@
+// Package metrics defines the provider interface App uses to emit its
+// built-in metrics and expose them over HTTP, decoupled from any
+// specific backend. The package holds only these interfaces and no
+// Provider implementation, so there is no default exposition format: the
+// metrics endpoint serves whatever the configured Provider's Handler
+// writes (Prometheus text, OpenTelemetry, StatsD, ...), without touching
+// App.
+package metrics
+
+import "net/http"
+
+// Counter is a monotonically increasing value, optionally labeled.
+type Counter interface {
+	Add(delta float64, labelValues ...string)
+}
+
+// Gauge is a value that can go up or down, optionally labeled.
+type Gauge interface {
+	Set(value float64, labelValues ...string)
+}
+
+// Histogram observes a distribution of values, optionally labeled.
+type Histogram interface {
+	Observe(value float64, labelValues ...string)
+}
+
+// Provider creates the instruments App emits built-in metrics on and
+// exposes them over HTTP.
+type Provider interface {
+	Counter(name string, labelNames ...string) Counter
+	Gauge(name string, labelNames ...string) Gauge
+	Histogram(name string, labelNames ...string) Histogram
+
+	// Handler serves the provider's current state, e.g. in Prometheus
+	// text format, for mounting as the metrics HTTP endpoint.
+	Handler() http.Handler
+}
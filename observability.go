@@ -0,0 +1,84 @@
+package kratos
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/pprof"
+
+	"github.com/go-kratos/kratos/v2/metrics"
+
+	"go.opentelemetry.io/otel/trace"
+	"golang.org/x/sync/errgroup"
+)
+
+const defaultMetricsAddr = ":9100"
+
+// TracerProvider returns the trace.TracerProvider set via WithTracing,
+// or nil if none was configured. App only stores it; nothing in App
+// starts spans with it.
+func (a *App) TracerProvider() trace.TracerProvider {
+	return a.opts.tracerProvider
+}
+
+// RequestLatency returns the kratos_request_duration_seconds histogram
+// (labels: server, method), or nil if no metrics.Provider was configured
+// via WithMetrics. App registers it but records nothing on it itself.
+func (a *App) RequestLatency() metrics.Histogram {
+	return a.requestLatency
+}
+
+// setupObservability registers the built-in metrics, and appends
+// Lifecycle hooks for the metrics and pprof HTTP endpoints, so they
+// start and stop alongside the rest of the application. It must run
+// before a.lifecycle.Start.
+func (a *App) setupObservability(g *errgroup.Group) {
+	if a.opts.metrics != nil {
+		a.registerBuiltinMetrics()
+		addr := a.opts.metricsAddr
+		if addr == "" {
+			addr = defaultMetricsAddr
+		}
+		a.appendHTTPDiagnosticServer(g, addr, a.opts.metrics.Handler())
+	}
+	if a.opts.profilingAddr != "" {
+		mux := http.NewServeMux()
+		mux.HandleFunc("/debug/pprof/", pprof.Index)
+		mux.HandleFunc("/debug/pprof/cmdline", pprof.Cmdline)
+		mux.HandleFunc("/debug/pprof/profile", pprof.Profile)
+		mux.HandleFunc("/debug/pprof/symbol", pprof.Symbol)
+		mux.HandleFunc("/debug/pprof/trace", pprof.Trace)
+		// Intentionally not added to a.instance.Endpoints: this listener
+		// is for operators, not for service discovery.
+		a.appendHTTPDiagnosticServer(g, a.opts.profilingAddr, mux)
+	}
+}
+
+// appendHTTPDiagnosticServer appends a Lifecycle hook that serves
+// handler on addr for as long as the app runs.
+func (a *App) appendHTTPDiagnosticServer(g *errgroup.Group, addr string, handler http.Handler) {
+	srv := &http.Server{Addr: addr, Handler: handler}
+	a.lifecycle.Append(Hook{
+		OnStart: func(context.Context) error {
+			g.Go(func() error {
+				if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+					return err
+				}
+				return nil
+			})
+			return nil
+		},
+		OnStop: func(ctx context.Context) error {
+			return srv.Shutdown(ctx)
+		},
+	})
+}
+
+func (a *App) registerBuiltinMetrics() {
+	a.opts.metrics.Gauge("kratos_app_info", "id", "name", "version").
+		Set(1, a.opts.id, a.opts.name, a.opts.version)
+	a.serverUp = a.opts.metrics.Gauge("kratos_server_up", "server")
+	a.registryRegisterTotal = a.opts.metrics.Counter("kratos_registry_register_total")
+	a.registryDeregisterTotal = a.opts.metrics.Counter("kratos_registry_deregister_total")
+	a.requestLatency = a.opts.metrics.Histogram("kratos_request_duration_seconds", "server", "method")
+}
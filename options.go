@@ -0,0 +1,184 @@
+package kratos
+
+import (
+	"context"
+	"net/url"
+	"os"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/config"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/go-kratos/kratos/v2/metrics"
+	"github.com/go-kratos/kratos/v2/registry"
+	"github.com/go-kratos/kratos/v2/transport"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Option is an application option.
+type Option func(o *options)
+
+// options is an application config options.
+type options struct {
+	id        string
+	name      string
+	version   string
+	metadata  map[string]string
+	endpoints []*url.URL
+
+	ctx  context.Context
+	sigs []os.Signal
+
+	logger     log.Logger
+	registry   registry.Registry
+	servers    []transport.Server
+	idProvider IDProvider
+
+	lifecycle        *Lifecycle
+	hookStartTimeout time.Duration
+
+	stopTimeout time.Duration
+	beforeStop  []func(context.Context) error
+	afterStop   []func(context.Context) error
+
+	healthCheckInterval    time.Duration
+	healthFailureThreshold int
+
+	metrics        metrics.Provider
+	metricsAddr    string
+	tracerProvider trace.TracerProvider
+	profilingAddr  string
+
+	configSource config.Source
+	configKeys   []string
+}
+
+// ID with service id.
+func ID(id string) Option {
+	return func(o *options) { o.id = id }
+}
+
+// Name with service name.
+func Name(name string) Option {
+	return func(o *options) { o.name = name }
+}
+
+// Version with service version.
+func Version(version string) Option {
+	return func(o *options) { o.version = version }
+}
+
+// Metadata with service metadata.
+func Metadata(md map[string]string) Option {
+	return func(o *options) { o.metadata = md }
+}
+
+// Endpoint with service endpoint.
+func Endpoint(endpoints ...*url.URL) Option {
+	return func(o *options) { o.endpoints = endpoints }
+}
+
+// Context with service context.
+func Context(ctx context.Context) Option {
+	return func(o *options) { o.ctx = ctx }
+}
+
+// Logger with service logger.
+func Logger(logger log.Logger) Option {
+	return func(o *options) { o.logger = logger }
+}
+
+// Server with transport servers.
+func Server(srv ...transport.Server) Option {
+	return func(o *options) { o.servers = srv }
+}
+
+// Registry with service registry.
+func Registry(r registry.Registry) Option {
+	return func(o *options) { o.registry = r }
+}
+
+// Signal with exit signals.
+func Signal(sigs ...os.Signal) Option {
+	return func(o *options) { o.sigs = sigs }
+}
+
+// WithHooks appends lifecycle hooks that run alongside the transport
+// servers: OnStart callbacks run in registration order before the app is
+// considered started, OnStop callbacks run in reverse order during
+// shutdown. Use this for work that the servers depend on, such as DB pool
+// warmup, cache priming, or starting a cron scheduler.
+func WithHooks(hooks ...Hook) Option {
+	return func(o *options) {
+		for _, h := range hooks {
+			o.lifecycle.Append(h)
+		}
+	}
+}
+
+// WithHookStartTimeout bounds how long a single hook's OnStart is allowed
+// to run. A hook that does not return within d causes Run to abort and
+// unwind already-started hooks. Zero (the default) means no timeout.
+func WithHookStartTimeout(d time.Duration) Option {
+	return func(o *options) { o.hookStartTimeout = d }
+}
+
+// WithStopTimeout bounds graceful shutdown: Stop derives a
+// context.WithTimeout from d, passes it to every server Stop and the
+// before/after-stop hooks, and stops waiting for the registry
+// deregistration call once it expires. Zero (the default) means wait
+// indefinitely.
+func WithStopTimeout(d time.Duration) Option {
+	return func(o *options) { o.stopTimeout = d }
+}
+
+// WithBeforeStop registers a function that runs, bound by the stop
+// timeout, before servers and the Lifecycle are stopped.
+func WithBeforeStop(fn func(context.Context) error) Option {
+	return func(o *options) { o.beforeStop = append(o.beforeStop, fn) }
+}
+
+// WithAfterStop registers a function that runs, bound by the stop
+// timeout, after servers and the Lifecycle have stopped and the
+// instance has been deregistered.
+func WithAfterStop(fn func(context.Context) error) Option {
+	return func(o *options) { o.afterStop = append(o.afterStop, fn) }
+}
+
+// WithHealthCheckInterval enables the background readiness monitor and
+// sets how often it runs. Zero (the default) disables it.
+func WithHealthCheckInterval(d time.Duration) Option {
+	return func(o *options) { o.healthCheckInterval = d }
+}
+
+// WithHealthFailureThreshold sets how many consecutive failed readiness
+// intervals it takes before the instance is deregistered. Defaults to 3.
+func WithHealthFailureThreshold(n int) Option {
+	return func(o *options) { o.healthFailureThreshold = n }
+}
+
+// WithMetrics sets the metrics.Provider App uses for its built-in
+// counters/gauges/histograms and for the metrics HTTP endpoint started
+// in Run.
+func WithMetrics(m metrics.Provider) Option {
+	return func(o *options) { o.metrics = m }
+}
+
+// WithMetricsAddr sets the address the metrics HTTP endpoint listens on
+// when a metrics.Provider is configured. Defaults to ":9100".
+func WithMetricsAddr(addr string) Option {
+	return func(o *options) { o.metricsAddr = addr }
+}
+
+// WithTracing sets the trace.TracerProvider returned by
+// App.TracerProvider. App installs no tracing middleware of its own.
+func WithTracing(tp trace.TracerProvider) Option {
+	return func(o *options) { o.tracerProvider = tp }
+}
+
+// WithProfiling starts a net/http/pprof listener on addr as part of Run.
+// It is a diagnostic-only port: it is never advertised in
+// ServiceInstance.Endpoints, so it never shows up in service discovery.
+func WithProfiling(addr string) Option {
+	return func(o *options) { o.profilingAddr = addr }
+}
@@ -0,0 +1,36 @@
+package kratos
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-kratos/kratos/v2/transport"
+)
+
+// ctxStopper is implemented by transport servers that accept a context
+// deadline on shutdown. transport.Server only guarantees the legacy no-arg
+// Stop(), which leaves no room for a Stop(ctx) method on the same type, so
+// the ctx-aware variant is Shutdown(ctx). stopServer checks for this
+// richer interface and falls back to the legacy Stop() when it's absent.
+type ctxStopper interface {
+	Shutdown(context.Context) error
+}
+
+// stopServer stops srv, honoring ctx's deadline even for servers that
+// only implement the legacy no-arg transport.Server.Stop(). Servers
+// implementing ctxStopper get ctx directly; others run their Stop in a
+// goroutine that is abandoned (not waited on) if ctx expires first, so a
+// single wedged server can't hold up the rest of shutdown.
+func stopServer(ctx context.Context, srv transport.Server) error {
+	if cs, ok := srv.(ctxStopper); ok {
+		return cs.Shutdown(ctx)
+	}
+	done := make(chan error, 1)
+	go func() { done <- srv.Stop() }()
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		return fmt.Errorf("kratos: server %T did not stop within the shutdown deadline: %w", srv, ctx.Err())
+	}
+}
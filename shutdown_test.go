@@ -0,0 +1,61 @@
+package kratos
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"testing"
+	"time"
+)
+
+// blockingServer is a transport.Server whose legacy Stop() never returns on
+// its own, simulating a wedged server.
+type blockingServer struct {
+	stopCalled chan struct{}
+}
+
+func (s *blockingServer) Start() error { return nil }
+
+func (s *blockingServer) Stop() error {
+	close(s.stopCalled)
+	select {}
+}
+
+func (s *blockingServer) Endpoint() (*url.URL, error) { return nil, nil }
+
+func TestStopServerAbandonsAfterDeadline(t *testing.T) {
+	srv := &blockingServer{stopCalled: make(chan struct{})}
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	err := stopServer(ctx, srv)
+	elapsed := time.Since(start)
+
+	<-srv.stopCalled // legacy Stop was in fact invoked
+	if err == nil {
+		t.Fatal("stopServer() = nil, want an error once the deadline passes")
+	}
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("stopServer() = %v, want it to wrap context.DeadlineExceeded", err)
+	}
+	if elapsed > time.Second {
+		t.Fatalf("stopServer() took %v, want it to return promptly after the deadline", elapsed)
+	}
+}
+
+// promptServer stops well within the deadline.
+type promptServer struct{}
+
+func (s *promptServer) Start() error                { return nil }
+func (s *promptServer) Stop() error                 { return nil }
+func (s *promptServer) Endpoint() (*url.URL, error) { return nil, nil }
+
+func TestStopServerReturnsOnCleanStop(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	if err := stopServer(ctx, &promptServer{}); err != nil {
+		t.Fatalf("stopServer() = %v, want nil", err)
+	}
+}